@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func handleIndex(c *gin.Context) {
+	data, err := fs.ReadFile(webFS, "index.html")
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "error reading index.html")
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", data)
+}
+
+func handleAPIIssues(c *gin.Context) {
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("List", func() (*rpc.Response, error) { return daemonClient.List(&rpc.ListArgs{}) })
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var issues []*types.Issue
+	if err := json.Unmarshal(resp.Data, &issues); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, issues)
+}
+
+func handleAPIIssueDetail(c *gin.Context) {
+	issueID := c.Param("id")
+	if issueID == "" {
+		respondError(c, http.StatusBadRequest, "issue ID required")
+		return
+	}
+
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("Show", func() (*rpc.Response, error) { return daemonClient.Show(&rpc.ShowArgs{ID: issueID}) })
+	if err != nil {
+		respondError(c, http.StatusNotFound, "issue not found: %v", err)
+		return
+	}
+
+	// RPC Show returns IssueDetails with labels, dependencies, dependents, comments
+	var details *types.IssueDetails
+	if err := json.Unmarshal(resp.Data, &details); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+func handleAPIReady(c *gin.Context) {
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("Ready", func() (*rpc.Response, error) { return daemonClient.Ready(&rpc.ReadyArgs{}) })
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var issues []*types.Issue
+	if err := json.Unmarshal(resp.Data, &issues); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, issues)
+}
+
+func handleAPIStats(c *gin.Context) {
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("Stats", func() (*rpc.Response, error) { return daemonClient.Stats() })
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var stats *types.Statistics
+	if err := json.Unmarshal(resp.Data, &stats); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func handleAPIEpics(c *gin.Context) {
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	// Use EpicStatus RPC to get epics with child counts
+	resp, err := timeRPC("EpicStatus", func() (*rpc.Response, error) { return daemonClient.EpicStatus(&rpc.EpicStatusArgs{}) })
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var epicStatuses []*types.EpicStatus
+	if err := json.Unmarshal(resp.Data, &epicStatuses); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	// Filter out tombstone epics - they are deleted and shouldn't appear in dashboard
+	var filtered []*types.EpicStatus
+	for _, es := range epicStatuses {
+		if es.Epic != nil && es.Epic.Status != "tombstone" {
+			filtered = append(filtered, es)
+		}
+	}
+
+	c.JSON(http.StatusOK, filtered)
+}
+
+func handleAPIConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, map[string]string{
+		"gtDashboardURL": gtURL,
+	})
+}
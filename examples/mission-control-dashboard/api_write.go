@@ -0,0 +1,320 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// createIssueRequest is the JSON body accepted by POST /api/issues.
+type createIssueRequest struct {
+	Title       string   `json:"title" binding:"required"`
+	Description string   `json:"description"`
+	IssueType   string   `json:"issueType"`
+	Priority    int      `json:"priority"`
+	Labels      []string `json:"labels"`
+	Assignee    string   `json:"assignee"`
+}
+
+func handleCreateIssue(c *gin.Context) {
+	var req createIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("Create", func() (*rpc.Response, error) {
+		return daemonClient.Create(&rpc.CreateArgs{
+			Title:       req.Title,
+			Description: req.Description,
+			IssueType:   req.IssueType,
+			Priority:    req.Priority,
+			Labels:      req.Labels,
+			Assignee:    req.Assignee,
+		})
+	})
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var issue *types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	broadcastMutation("create", issue.ID, issue.EpicID, "")
+	c.JSON(http.StatusCreated, issue)
+}
+
+// updateIssueRequest is the JSON body accepted by PATCH /api/issues/:id.
+// Pointer fields are only applied to the RPC call when present, so a client
+// can patch a single field (e.g. just status) without clobbering the rest.
+type updateIssueRequest struct {
+	Status   *string   `json:"status"`
+	Priority *int      `json:"priority"`
+	Labels   *[]string `json:"labels"`
+	Assignee *string   `json:"assignee"`
+}
+
+func handleUpdateIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	var req updateIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("Update", func() (*rpc.Response, error) {
+		return daemonClient.Update(&rpc.UpdateArgs{
+			ID:       id,
+			Status:   req.Status,
+			Priority: req.Priority,
+			Labels:   req.Labels,
+			Assignee: req.Assignee,
+		})
+	})
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var issue *types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	broadcastMutation("update", id, issue.EpicID, updateDetail(req))
+	c.JSON(http.StatusOK, issue)
+}
+
+// updateDetail renders the fields an update actually changed, so Hub's
+// dedup key can tell two distinct updates to the same issue apart instead of
+// colliding on (type, issueID) alone.
+func updateDetail(req updateIssueRequest) string {
+	var parts []string
+	if req.Status != nil {
+		parts = append(parts, "status="+*req.Status)
+	}
+	if req.Priority != nil {
+		parts = append(parts, fmt.Sprintf("priority=%d", *req.Priority))
+	}
+	if req.Assignee != nil {
+		parts = append(parts, "assignee="+*req.Assignee)
+	}
+	if req.Labels != nil {
+		parts = append(parts, "labels="+strings.Join(*req.Labels, ","))
+	}
+	return strings.Join(parts, ",")
+}
+
+func handleDeleteIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	daemonClientMu.Lock()
+	if daemonClient == nil {
+		daemonClientMu.Unlock()
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	_, err := timeRPC("CloseIssue", func() (*rpc.Response, error) { return daemonClient.CloseIssue(&rpc.CloseArgs{ID: id}) })
+	daemonClientMu.Unlock()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	broadcastMutation("close", id, epicIDFor(id), "")
+	c.Status(http.StatusNoContent)
+}
+
+type addCommentRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+func handleAddComment(c *gin.Context) {
+	id := c.Param("id")
+
+	var req addCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	daemonClientMu.Lock()
+	if daemonClient == nil {
+		daemonClientMu.Unlock()
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("Comment", func() (*rpc.Response, error) {
+		return daemonClient.Comment(&rpc.CommentArgs{ID: id, Text: req.Text})
+	})
+	daemonClientMu.Unlock()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var comment *types.Comment
+	if err := json.Unmarshal(resp.Data, &comment); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	broadcastMutation("comment", id, epicIDFor(id), req.Text)
+	c.JSON(http.StatusCreated, comment)
+}
+
+type addDependencyRequest struct {
+	DependsOn string `json:"dependsOn" binding:"required"`
+	Type      string `json:"type"`
+}
+
+func handleAddDependency(c *gin.Context) {
+	id := c.Param("id")
+
+	var req addDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	daemonClientMu.Lock()
+	if daemonClient == nil {
+		daemonClientMu.Unlock()
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	_, err := timeRPC("AddDependency", func() (*rpc.Response, error) {
+		return daemonClient.AddDependency(&rpc.AddDependencyArgs{
+			ID:        id,
+			DependsOn: req.DependsOn,
+			Type:      req.Type,
+		})
+	})
+	daemonClientMu.Unlock()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	broadcastMutation("dependency", id, epicIDFor(id), req.DependsOn+"|"+req.Type)
+	c.Status(http.StatusNoContent)
+}
+
+type createEpicRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+}
+
+func handleCreateEpic(c *gin.Context) {
+	var req createEpicRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		respondError(c, http.StatusInternalServerError, "daemon not connected")
+		return
+	}
+
+	resp, err := timeRPC("Create", func() (*rpc.Response, error) {
+		return daemonClient.Create(&rpc.CreateArgs{
+			Title:       req.Title,
+			Description: req.Description,
+			IssueType:   "epic",
+		})
+	})
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "RPC error: %v", err)
+		return
+	}
+
+	var epic *types.Issue
+	if err := json.Unmarshal(resp.Data, &epic); err != nil {
+		respondError(c, http.StatusInternalServerError, "JSON error: %v", err)
+		return
+	}
+
+	broadcastMutation("create", epic.ID, epic.EpicID, "")
+	c.JSON(http.StatusCreated, epic)
+}
+
+// broadcastMutation pushes a synthetic mutation event through the hub as
+// soon as an API call mutates state, so WS/SSE clients don't have to wait
+// for the next pollMutations tick to see it. epicID and detail are set so
+// Hub can both filter on ?epic= and dedup on something more specific than
+// (type, issueID).
+func broadcastMutation(mutType, issueID, epicID, detail string) {
+	event := rpc.MutationEvent{
+		Type:      mutType,
+		IssueID:   issueID,
+		EpicID:    epicID,
+		Detail:    detail,
+		Timestamp: time.Now(),
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	mutationHub.Broadcast(event, data)
+}
+
+// epicIDFor best-effort resolves the epic an issue belongs to, for handlers
+// that only have an issue ID on hand (not the full issue) when they need to
+// populate a broadcastMutation's EpicID. Any failure just means the
+// synthetic event goes out without an epic, same as before this existed.
+func epicIDFor(id string) string {
+	daemonClientMu.Lock()
+	defer daemonClientMu.Unlock()
+
+	if daemonClient == nil {
+		return ""
+	}
+
+	resp, err := timeRPC("Show", func() (*rpc.Response, error) {
+		return daemonClient.Show(&rpc.ShowArgs{ID: id})
+	})
+	if err != nil {
+		return ""
+	}
+
+	var details *types.IssueDetails
+	if err := json.Unmarshal(resp.Data, &details); err != nil || details == nil || details.Issue == nil {
+		return ""
+	}
+	return details.Issue.EpicID
+}
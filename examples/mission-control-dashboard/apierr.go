@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiError is the JSON envelope returned for every non-2xx API response, so
+// dashboard clients can rely on a single shape instead of parsing plaintext
+// bodies from http.Error.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// respondError writes a JSON apiError envelope and aborts the gin context.
+func respondError(c *gin.Context, status int, format string, args ...interface{}) {
+	c.AbortWithStatusJSON(status, apiError{Error: fmt.Sprintf(format, args...)})
+}
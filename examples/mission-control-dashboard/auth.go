@@ -0,0 +1,269 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	authToken        = flag.String("auth-token", "", "Bearer token required on /api/* routes other than /api/events (also read from BEADS_DASHBOARD_TOKEN)")
+	basicAuthUser    = flag.String("basic-auth-user", "", "HTTP basic auth username (optional, requires -basic-auth-pass)")
+	basicAuthPass    = flag.String("basic-auth-pass", "", "HTTP basic auth password")
+	jwtSecret        = flag.String("jwt-secret", "", "HS256 shared secret for verifying bearer JWTs (also read from BEADS_DASHBOARD_JWT_SECRET)")
+	jwtPublicKeyPath = flag.String("jwt-public-key", "", "Path to a PEM-encoded RSA public key for verifying RS256 bearer JWTs")
+	allowedOrigins   = flag.String("allowed-origins", "", "Comma-separated list of origins allowed to open the dashboard WebSocket")
+
+	wsTokenSecret []byte
+	jwtPublicKey  *rsa.PublicKey
+)
+
+// wsTokenTTL is how long a token minted by /api/ws-token stays valid. It only
+// needs to survive the brief window between fetching the token and opening
+// the WebSocket, so it's kept short.
+const wsTokenTTL = 30 * time.Second
+
+// wsTokenClaims is the payload signed into a /api/ws-token response.
+type wsTokenClaims struct {
+	jwt.RegisteredClaims
+}
+
+// initAuth resolves auth-related flags/env vars and generates the signing
+// key used for short-lived WebSocket tokens. Must run after flag.Parse.
+func initAuth() error {
+	wsTokenSecret = make([]byte, 32)
+	if _, err := rand.Read(wsTokenSecret); err != nil {
+		return fmt.Errorf("generating ws token secret: %w", err)
+	}
+
+	if *jwtPublicKeyPath != "" {
+		pemBytes, err := os.ReadFile(*jwtPublicKeyPath)
+		if err != nil {
+			return fmt.Errorf("reading jwt public key: %w", err)
+		}
+		block, _ := pem.Decode(pemBytes)
+		if block == nil {
+			return fmt.Errorf("jwt public key is not valid PEM")
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("parsing jwt public key: %w", err)
+		}
+		rsaPub, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("jwt public key must be RSA")
+		}
+		jwtPublicKey = rsaPub
+	}
+
+	return nil
+}
+
+func resolveAuthToken() string {
+	if *authToken != "" {
+		return *authToken
+	}
+	return os.Getenv("BEADS_DASHBOARD_TOKEN")
+}
+
+func resolveJWTSecret() []byte {
+	if *jwtSecret != "" {
+		return []byte(*jwtSecret)
+	}
+	if s := os.Getenv("BEADS_DASHBOARD_JWT_SECRET"); s != "" {
+		return []byte(s)
+	}
+	return nil
+}
+
+// authEnabled reports whether any auth mechanism has been configured. When
+// nothing is configured the dashboard behaves as before: open on localhost
+// for local development.
+func authEnabled() bool {
+	return resolveAuthToken() != "" || resolveJWTSecret() != nil || jwtPublicKey != nil || (*basicAuthUser != "" && *basicAuthPass != "")
+}
+
+// apiAuthMiddleware enforces whichever auth mechanisms are configured on
+// every request in the /api route group (/api/events sits outside the group
+// and uses sseAuthMiddleware). A request is accepted if it satisfies any one of
+// them, so operators can run bearer-token and basic auth side by side during
+// a migration.
+func apiAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authEnabled() {
+			c.Next()
+			return
+		}
+
+		if checkBearerToken(c.GetHeader("Authorization")) || checkBasicAuth(c) {
+			c.Next()
+			return
+		}
+
+		respondError(c, http.StatusUnauthorized, "authentication required")
+	}
+}
+
+func checkBearerToken(header string) bool {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	raw := strings.TrimPrefix(header, prefix)
+
+	if want := resolveAuthToken(); want != "" && subtle.ConstantTimeCompare([]byte(raw), []byte(want)) == 1 {
+		return true
+	}
+
+	return verifyJWT(raw)
+}
+
+func verifyJWT(raw string) bool {
+	secret := resolveJWTSecret()
+	if secret == nil && jwtPublicKey == nil {
+		return false
+	}
+
+	token, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if secret == nil {
+				return nil, fmt.Errorf("HS256 not configured")
+			}
+			return secret, nil
+		case *jwt.SigningMethodRSA:
+			if jwtPublicKey == nil {
+				return nil, fmt.Errorf("RS256 not configured")
+			}
+			return jwtPublicKey, nil
+		default:
+			return nil, fmt.Errorf("unsupported signing method: %v", t.Header["alg"])
+		}
+	})
+	return err == nil && token.Valid
+}
+
+func checkBasicAuth(c *gin.Context) bool {
+	if *basicAuthUser == "" || *basicAuthPass == "" {
+		return false
+	}
+	user, pass, ok := c.Request.BasicAuth()
+	if !ok {
+		return false
+	}
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(*basicAuthUser)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(*basicAuthPass)) == 1
+	return userOK && passOK
+}
+
+// handleWSToken issues a short-lived HS256 JWT that authorizes WebSocket
+// upgrades and /api/events requests until it expires after wsTokenTTL; it is
+// not single-use. Sits behind apiAuthMiddleware, so only already-trusted
+// clients can mint one.
+func handleWSToken(c *gin.Context) {
+	claims := wsTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(wsTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(wsTokenSecret)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "minting ws token: %v", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": signed, "expiresIn": int(wsTokenTTL.Seconds())})
+}
+
+// wsAuthMiddleware requires a valid ws-token on the /ws upgrade request
+// whenever auth is configured, since browsers can't attach an Authorization
+// header to a WebSocket handshake.
+func wsAuthMiddleware() gin.HandlerFunc {
+	return queryTokenAuthMiddleware("ws")
+}
+
+// sseAuthMiddleware requires a valid ws-token on the /api/events request
+// whenever auth is configured. A browser's native EventSource can't attach an
+// Authorization header or basic-auth credentials either, so SSE needs the
+// same query-param token path as the WebSocket upgrade instead of
+// apiAuthMiddleware.
+func sseAuthMiddleware() gin.HandlerFunc {
+	return queryTokenAuthMiddleware("sse")
+}
+
+// queryTokenAuthMiddleware backs both wsAuthMiddleware and sseAuthMiddleware:
+// it requires a valid ws-token passed as ?token=, which is the only way a
+// WebSocket upgrade or an EventSource request can carry a credential.
+func queryTokenAuthMiddleware(kind string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authEnabled() {
+			c.Next()
+			return
+		}
+
+		raw := c.Query("token")
+		if raw == "" {
+			respondError(c, http.StatusUnauthorized, "%s token required", kind)
+			return
+		}
+
+		token, err := jwt.ParseWithClaims(raw, &wsTokenClaims{}, func(t *jwt.Token) (interface{}, error) {
+			return wsTokenSecret, nil
+		})
+		if err != nil || !token.Valid {
+			respondError(c, http.StatusUnauthorized, "invalid or expired %s token", kind)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// originAllowed backs upgrader.CheckOrigin with a real allowlist fed by
+// -allowed-origins, instead of unconditionally returning true.
+func originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// No Origin header means this isn't a browser request (e.g. a CLI
+		// tool or same-process health check); nothing to check it against.
+		return true
+	}
+
+	if *allowedOrigins == "" {
+		// Unconfigured, so only allow the dashboard's own origin: browsers
+		// always send Origin on a WebSocket handshake, including same-origin
+		// requests from the bundled web UI, and -allowed-origins should only
+		// need setting to open the socket up beyond that.
+		return origin == selfOrigin(r)
+	}
+
+	for _, allowed := range strings.Split(*allowedOrigins, ",") {
+		if strings.TrimSpace(allowed) == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// selfOrigin is the origin the dashboard itself is served on, derived from
+// the request's own Host header rather than the -host/-port flags: a
+// dashboard bound to -host 0.0.0.0 (or any other non-client-facing address)
+// would otherwise never match the Origin a real browser sends.
+func selfOrigin(r *http.Request) string {
+	scheme := "http"
+	if tlsEnabled() {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
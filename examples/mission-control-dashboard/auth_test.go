@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOriginAllowed(t *testing.T) {
+	savedAllowed, savedCert, savedKey, savedHost := *allowedOrigins, *tlsCert, *tlsKey, *host
+	defer func() {
+		*allowedOrigins, *tlsCert, *tlsKey, *host = savedAllowed, savedCert, savedKey, savedHost
+	}()
+
+	tests := []struct {
+		name           string
+		allowedOrigins string
+		origin         string
+		requestHost    string
+		want           bool
+	}{
+		{"no origin header is allowed", "", "", "dashboard.internal:8082", true},
+		{"unconfigured allows own origin", "", "http://dashboard.internal:8082", "dashboard.internal:8082", true},
+		{"unconfigured rejects other origin", "", "http://evil.example", "dashboard.internal:8082", false},
+		{"unconfigured matches bound-to-0.0.0.0 deployment via Host header", "", "http://dashboard.internal:8082", "dashboard.internal:8082", true},
+		{"configured allows listed origin", "http://a.example,http://b.example", "http://b.example", "dashboard.internal:8082", true},
+		{"configured rejects unlisted origin", "http://a.example", "http://evil.example", "dashboard.internal:8082", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*allowedOrigins = tt.allowedOrigins
+			*tlsCert, *tlsKey = "", ""
+			// Bind to all interfaces, as a deployment reachable beyond
+			// localhost would; the own-origin check goes by the Host header.
+			*host = "0.0.0.0"
+
+			r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			r.Host = tt.requestHost
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+
+			if got := originAllowed(r); got != tt.want {
+				t.Errorf("originAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelfOriginUsesRequestHost(t *testing.T) {
+	savedCert, savedKey := *tlsCert, *tlsKey
+	defer func() { *tlsCert, *tlsKey = savedCert, savedKey }()
+	*tlsCert, *tlsKey = "", ""
+
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Host = "dashboard.internal:8082"
+
+	if got, want := selfOrigin(r), "http://dashboard.internal:8082"; got != want {
+		t.Errorf("selfOrigin() = %q, want %q", got, want)
+	}
+}
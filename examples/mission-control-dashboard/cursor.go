@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// loadCursor reads the last-seen mutation timestamp (unix millis) from path.
+// Missing or unreadable files are treated as "no cursor yet" rather than an
+// error, since that's exactly the state on first run.
+func loadCursor(path string) int64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	cursor, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return cursor
+}
+
+// saveCursor persists the last-seen mutation timestamp so a dashboard
+// restart resumes from where it left off instead of replaying or dropping
+// events.
+func saveCursor(path string, cursor int64) {
+	_ = os.WriteFile(path, []byte(strconv.FormatInt(cursor, 10)), 0o644)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessMaxStaleness is how long /readyz tolerates a stale last-successful
+// mutation poll before reporting not-ready. A daemon connection that stopped
+// actually delivering mutations is as good as no connection at all.
+const readinessMaxStaleness = 30 * time.Second
+
+var lastSuccessfulPollUnixNano int64
+
+// markPollSuccess records that the dashboard just heard from the daemon,
+// called from subscribeLoop/pollMutations after every successful RPC.
+func markPollSuccess() {
+	atomic.StoreInt64(&lastSuccessfulPollUnixNano, time.Now().UnixNano())
+}
+
+func lastSuccessfulPoll() time.Time {
+	nanos := atomic.LoadInt64(&lastSuccessfulPollUnixNano)
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// handleHealthz reports whether the process is alive, suitable for a
+// Kubernetes liveness probe. It never depends on the daemon connection.
+func handleHealthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "alive"})
+}
+
+// handleReadyz reports whether the dashboard can currently serve data:
+// connected to the daemon and has heard from it within readinessMaxStaleness.
+// Suitable for a Kubernetes readiness probe.
+func handleReadyz(c *gin.Context) {
+	daemonClientMu.Lock()
+	connected := daemonClient != nil
+	daemonClientMu.Unlock()
+
+	last := lastSuccessfulPoll()
+	stale := last.IsZero() || time.Since(last) > readinessMaxStaleness
+
+	status := http.StatusOK
+	state := "ready"
+	if !connected || stale {
+		status = http.StatusServiceUnavailable
+		state = "not ready"
+	}
+
+	c.JSON(status, gin.H{
+		"status":          state,
+		"daemonConnected": connected,
+		"lastPoll":        last,
+	})
+}
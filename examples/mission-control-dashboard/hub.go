@@ -0,0 +1,217 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// hubClientQueueSize bounds how many pending events a single subscriber can
+// buffer. A subscriber whose queue is full is treated as a slow consumer and
+// disconnected, so one stalled browser tab can't back up delivery for
+// everyone else.
+const hubClientQueueSize = 64
+
+// hubReplayBufferSize is how many recent events the hub keeps around so an
+// SSE client reconnecting with Last-Event-ID can catch up on what it missed.
+const hubReplayBufferSize = 256
+
+// dedupWindow bounds how long Broadcast remembers a mutation key. An API
+// write handler broadcasts a mutation synthetically the moment its RPC
+// succeeds, and the same mutation shows up again shortly after from
+// pollMutations/subscribeLoop once the daemon's own log picks it up; this
+// window lets Broadcast recognize the second one as a repeat instead of
+// delivering it to subscribers twice.
+const dedupWindow = 5 * time.Second
+
+// mutationFilter narrows which events a subscriber receives, driven by the
+// ?issue=, ?type=, and ?epic= query params on /ws and /api/events.
+type mutationFilter struct {
+	issues map[string]bool
+	types  map[string]bool
+	epics  map[string]bool
+}
+
+func parseMutationFilter(issue, mutType, epic string) mutationFilter {
+	return mutationFilter{
+		issues: splitFilterSet(issue),
+		types:  splitFilterSet(mutType),
+		epics:  splitFilterSet(epic),
+	}
+}
+
+func splitFilterSet(csv string) map[string]bool {
+	if csv == "" {
+		return nil
+	}
+	set := make(map[string]bool)
+	for _, v := range strings.Split(csv, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
+
+func (f mutationFilter) matches(event rpc.MutationEvent) bool {
+	if f.issues != nil && !f.issues[event.IssueID] {
+		return false
+	}
+	if f.types != nil && !f.types[event.Type] {
+		return false
+	}
+	if f.epics != nil && !f.epics[event.EpicID] {
+		return false
+	}
+	return true
+}
+
+// hubEvent pairs a mutation with the monotonic sequence number the replay
+// buffer and the SSE "id:" field key off of.
+type hubEvent struct {
+	seq      int64
+	data     []byte
+	mutation rpc.MutationEvent
+}
+
+// hubSubscriber is a single WS or SSE client's mailbox.
+type hubSubscriber struct {
+	ch     chan hubEvent
+	filter mutationFilter
+}
+
+// Hub fans mutation events out to both WebSocket and SSE subscribers. Each
+// subscriber has its own bounded queue so a slow client can be dropped
+// without blocking delivery to everyone else or the mutation poller.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*hubSubscriber]bool
+	nextSeq     int64
+	replay      []hubEvent
+	recent      map[string]time.Time
+}
+
+func newHub() *Hub {
+	return &Hub{
+		subscribers: make(map[*hubSubscriber]bool),
+		recent:      make(map[string]time.Time),
+	}
+}
+
+// mutationKey identifies a mutation for dedup purposes. It deliberately
+// excludes the timestamp: the synthetic broadcast an API handler fires and
+// the daemon-sourced one that follows it carry different timestamps for the
+// same underlying change. Type+IssueID alone isn't enough to key on: two
+// distinct mutations of the same type on the same issue (two comments back
+// to back, two quick status flips) would collide and the second would
+// silently never reach subscribers. Detail carries whatever distinguishes
+// the mutation's actual content, so only a genuine repeat of the same write
+// collides.
+func mutationKey(m rpc.MutationEvent) string {
+	return m.Type + "|" + m.IssueID + "|" + m.EpicID + "|" + m.Detail
+}
+
+// Subscribe registers a new subscriber and returns any buffered events with
+// seq > afterSeq, so SSE resume can replay what the client missed before the
+// live feed starts. WebSocket subscribers pass afterSeq 0 and ignore the
+// backlog.
+func (h *Hub) Subscribe(filter mutationFilter, afterSeq int64) (*hubSubscriber, []hubEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &hubSubscriber{ch: make(chan hubEvent, hubClientQueueSize), filter: filter}
+	h.subscribers[sub] = true
+
+	var backlog []hubEvent
+	for _, e := range h.replay {
+		if e.seq > afterSeq && filter.matches(e.mutation) {
+			backlog = append(backlog, e)
+		}
+	}
+	return sub, backlog
+}
+
+// Unsubscribe removes sub and closes its channel. Safe to call more than
+// once for the same subscriber.
+func (h *Hub) Unsubscribe(sub *hubSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Broadcast fans mutation out to every matching subscriber. A subscriber
+// whose queue is already full is dropped as a slow consumer rather than
+// allowed to block this call. A mutation seen again within dedupWindow of an
+// earlier one with the same key is dropped rather than fanned out twice.
+func (h *Hub) Broadcast(mutation rpc.MutationEvent, data []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	key := mutationKey(mutation)
+	if until, ok := h.recent[key]; ok && now.Before(until) {
+		return
+	}
+	h.recent[key] = now.Add(dedupWindow)
+	for k, until := range h.recent {
+		if now.After(until) {
+			delete(h.recent, k)
+		}
+	}
+
+	h.nextSeq++
+	event := hubEvent{seq: h.nextSeq, data: data, mutation: mutation}
+	h.replay = append(h.replay, event)
+	if len(h.replay) > hubReplayBufferSize {
+		h.replay = h.replay[len(h.replay)-hubReplayBufferSize:]
+	}
+
+	for sub := range h.subscribers {
+		if !sub.filter.matches(mutation) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			delete(h.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// SubscriberCount returns the number of currently connected WS/SSE clients.
+func (h *Hub) SubscriberCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// QueueDepth returns the total number of events queued across every
+// subscriber's channel, for the broadcastQueueDepth gauge.
+func (h *Hub) QueueDepth() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	depth := 0
+	for sub := range h.subscribers {
+		depth += len(sub.ch)
+	}
+	return depth
+}
+
+// Close disconnects every subscriber by closing its channel, so WS/SSE
+// handlers unwind cleanly instead of hanging open during shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+var mutationHub = newHub()
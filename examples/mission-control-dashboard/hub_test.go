@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+func TestMutationFilterMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter mutationFilter
+		event  rpc.MutationEvent
+		want   bool
+	}{
+		{"empty filter matches anything", mutationFilter{}, rpc.MutationEvent{Type: "create", IssueID: "bd-1"}, true},
+		{"issue filter matches", parseMutationFilter("bd-1", "", ""), rpc.MutationEvent{IssueID: "bd-1"}, true},
+		{"issue filter rejects", parseMutationFilter("bd-1", "", ""), rpc.MutationEvent{IssueID: "bd-2"}, false},
+		{"type filter matches", parseMutationFilter("", "comment", ""), rpc.MutationEvent{Type: "comment"}, true},
+		{"type filter rejects", parseMutationFilter("", "comment", ""), rpc.MutationEvent{Type: "create"}, false},
+		{"epic filter matches", parseMutationFilter("", "", "bd-epic-1"), rpc.MutationEvent{EpicID: "bd-epic-1"}, true},
+		{"epic filter rejects", parseMutationFilter("", "", "bd-epic-1"), rpc.MutationEvent{EpicID: "bd-epic-2"}, false},
+		{"all filters must match", parseMutationFilter("bd-1", "comment", "bd-epic-1"), rpc.MutationEvent{IssueID: "bd-1", Type: "comment", EpicID: "bd-epic-1"}, true},
+		{"all filters, one mismatch", parseMutationFilter("bd-1", "comment", "bd-epic-1"), rpc.MutationEvent{IssueID: "bd-1", Type: "close", EpicID: "bd-epic-1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(tt.event); got != tt.want {
+				t.Errorf("matches(%+v) = %v, want %v", tt.event, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHubBroadcastDedup(t *testing.T) {
+	h := newHub()
+	sub, _ := h.Subscribe(mutationFilter{}, 0)
+	defer h.Unsubscribe(sub)
+
+	h.Broadcast(rpc.MutationEvent{Type: "comment", IssueID: "bd-1", Detail: "first"}, []byte("1"))
+	h.Broadcast(rpc.MutationEvent{Type: "comment", IssueID: "bd-1", Detail: "first"}, []byte("1-dup"))
+
+	select {
+	case <-sub.ch:
+	default:
+		t.Fatal("expected the first broadcast to be delivered")
+	}
+	select {
+	case e := <-sub.ch:
+		t.Fatalf("expected duplicate mutation to be deduped, got %+v", e.mutation)
+	default:
+	}
+}
+
+func TestHubBroadcastDistinctDetailNotDeduped(t *testing.T) {
+	h := newHub()
+	sub, _ := h.Subscribe(mutationFilter{}, 0)
+	defer h.Unsubscribe(sub)
+
+	h.Broadcast(rpc.MutationEvent{Type: "comment", IssueID: "bd-1", Detail: "first"}, []byte("1"))
+	h.Broadcast(rpc.MutationEvent{Type: "comment", IssueID: "bd-1", Detail: "second"}, []byte("2"))
+
+	count := 0
+	for {
+		select {
+		case <-sub.ch:
+			count++
+		default:
+			if count != 2 {
+				t.Fatalf("got %d events, want 2 distinct mutations delivered", count)
+			}
+			return
+		}
+	}
+}
+
+func TestHubBroadcastDedupExpiresAfterWindow(t *testing.T) {
+	h := newHub()
+	sub, _ := h.Subscribe(mutationFilter{}, 0)
+	defer h.Unsubscribe(sub)
+
+	mutation := rpc.MutationEvent{Type: "comment", IssueID: "bd-1"}
+	h.recent[mutationKey(mutation)] = time.Now().Add(-time.Second)
+
+	h.Broadcast(mutation, []byte("1"))
+
+	select {
+	case <-sub.ch:
+	default:
+		t.Fatal("expected broadcast to be delivered once the dedup window has elapsed")
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// logger emits structured JSON logs to stderr so the dashboard can be piped
+// into the same log pipeline as the rest of the stack, instead of the bare
+// fmt.Fprintf(os.Stderr, ...) calls it used to make on every error path.
+var logger = slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
+// loggingMiddleware logs one structured line per request: method, path,
+// status, duration, and remote address.
+func loggingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		logger.Info("http request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration", time.Since(start).String(),
+			"remoteAddr", c.ClientIP(),
+		)
+	}
+}
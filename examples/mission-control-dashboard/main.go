@@ -3,6 +3,7 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"encoding/json"
 	"flag"
@@ -10,16 +11,22 @@ import (
 	"io/fs"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sync"
+	"syscall"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/rpc"
-	"github.com/steveyegge/beads/internal/types"
 )
 
+// shutdownTimeout bounds how long graceful shutdown waits for in-flight
+// requests and WebSocket/SSE connections to drain before forcing a close.
+const shutdownTimeout = 10 * time.Second
+
 //go:embed web
 var webFiles embed.FS
 
@@ -34,13 +41,9 @@ var (
 	upgrader = websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
-		CheckOrigin:     func(r *http.Request) bool { return true },
+		CheckOrigin:     originAllowed,
 	}
 
-	wsClients   = make(map[*websocket.Conn]bool)
-	wsClientsMu sync.Mutex
-	wsBroadcast = make(chan []byte, 256)
-
 	daemonClient   *rpc.Client
 	daemonClientMu sync.Mutex // Protects concurrent RPC calls
 	webFS          fs.FS
@@ -50,13 +53,18 @@ var (
 func main() {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Fprintf(os.Stderr, "PANIC in main: %v\n", r)
+			logger.Error("panic in main", "recovered", r)
 		}
 	}()
 
 	flag.Parse()
 	gtURL = *gtDashURL
 
+	if err := initAuth(); err != nil {
+		logger.Error("failed to initialize auth", "error", err)
+		os.Exit(1)
+	}
+
 	// Set up web file system
 	if *devMode {
 		fmt.Println("Running in DEVELOPMENT mode: serving web files from disk")
@@ -65,7 +73,7 @@ func main() {
 		var err error
 		webFS, err = fs.Sub(webFiles, "web")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error accessing embedded web files: %v\n", err)
+			logger.Error("failed to access embedded web files", "error", err)
 			os.Exit(1)
 		}
 	}
@@ -76,8 +84,7 @@ func main() {
 		if foundDB := beads.FindDatabasePath(); foundDB != "" {
 			dbPathResolved = foundDB
 		} else {
-			fmt.Fprintf(os.Stderr, "Error: no beads database found\n")
-			fmt.Fprintf(os.Stderr, "Hint: run 'bd init' in your project or specify -db flag\n")
+			logger.Error("no beads database found", "hint", "run 'bd init' in your project or specify -db flag")
 			os.Exit(1)
 		}
 	}
@@ -90,38 +97,64 @@ func main() {
 
 	// Connect to daemon
 	if err := connectToDaemon(socketPathResolved, dbPathResolved); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		logger.Error("failed to connect to daemon", "error", err)
 		os.Exit(1)
 	}
 
-	// Start WebSocket broadcaster and mutation polling
-	go handleWebSocketBroadcast()
-	go pollMutations()
-
-	// HTTP routes
-	http.HandleFunc("/", handleIndex)
-	http.HandleFunc("/api/issues", handleAPIIssues)
-	http.HandleFunc("/api/issues/", handleAPIIssueDetail)
-	http.HandleFunc("/api/ready", handleAPIReady)
-	http.HandleFunc("/api/stats", handleAPIStats)
-	http.HandleFunc("/api/epics", handleAPIEpics)
-	http.HandleFunc("/api/config", handleAPIConfig)
-	http.HandleFunc("/ws", handleWebSocket)
-	http.Handle("/static/", http.StripPrefix("/", http.FileServer(http.FS(webFS))))
+	// Start mutation delivery; the hub fans each event out to WS/SSE
+	// subscribers. Prefer the push-based Subscribe RPC and fall back to
+	// fixed-interval polling for daemons that predate it.
+	cursorPath := filepath.Join(filepath.Dir(dbPathResolved), ".dashboard-cursor")
+	if daemonSupportsSubscribe {
+		go subscribeLoop(cursorPath)
+	} else {
+		go pollMutations(cursorPath)
+	}
+	go sampleHubMetrics()
+	go superviseDaemonConnection(socketPathResolved, dbPathResolved)
+
+	router := newRouter()
 
 	addr := fmt.Sprintf("%s:%d", *host, *port)
+	scheme := "http"
+	if tlsEnabled() {
+		scheme = "https"
+	}
+
+	redirectAddr := ""
+	if tlsEnabled() && *httpRedirectPort != 0 {
+		redirectAddr = fmt.Sprintf("%s:%d", *host, *httpRedirectPort)
+	}
+
 	fmt.Printf("\n")
 	fmt.Printf("  📿 Beads Dashboard - Mission Control Style\n")
 	fmt.Printf("  ────────────────────────────────────────────\n")
-	fmt.Printf("  Local:     http://%s\n", addr)
+	fmt.Printf("  Local:     %s://%s\n", scheme, addr)
 	fmt.Printf("  GT Link:   %s\n", gtURL)
 	fmt.Printf("  WebSocket: ws://%s/ws\n", addr)
 	fmt.Printf("\n")
 	fmt.Printf("  Press Ctrl+C to stop\n\n")
 
-	if err := http.ListenAndServe(addr, nil); err != nil {
-		fmt.Fprintf(os.Stderr, "Error starting server: %v\n", err)
-		os.Exit(1)
+	srv := &http.Server{Addr: addr, Handler: router}
+
+	go func() {
+		if err := serve(srv, redirectAddr); err != nil && err != http.ErrServerClosed {
+			logger.Error("server stopped unexpectedly", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	logger.Info("shutting down")
+	mutationHub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("graceful shutdown failed", "error", err)
 	}
 }
 
@@ -140,214 +173,44 @@ func connectToDaemon(socketPath, dbPath string) error {
 	absDBPath, _ := filepath.Abs(dbPath)
 	client.SetDatabasePath(absDBPath)
 	daemonClient = client
+	daemonSupportsSubscribe = versionAtLeast(health.Version, subscribeMinVersion)
 
 	fmt.Printf("  ✓ Connected to daemon (v%s)\n", health.Version)
 	return nil
 }
 
-func handleIndex(w http.ResponseWriter, r *http.Request) {
-	if r.URL.Path != "/" {
-		http.NotFound(w, r)
-		return
-	}
-	data, err := fs.ReadFile(webFS, "index.html")
+func handleWebSocket(c *gin.Context) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		http.Error(w, "Error reading index.html", http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write(data)
-}
+	defer conn.Close()
 
-func handleAPIIssues(w http.ResponseWriter, r *http.Request) {
-	daemonClientMu.Lock()
-	defer daemonClientMu.Unlock()
-
-	if daemonClient == nil {
-		http.Error(w, "Daemon not connected", http.StatusInternalServerError)
-		return
-	}
-
-	resp, err := daemonClient.List(&rpc.ListArgs{})
-	if err != nil {
-		http.Error(w, fmt.Sprintf("RPC error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	var issues []*types.Issue
-	if err := json.Unmarshal(resp.Data, &issues); err != nil {
-		http.Error(w, fmt.Sprintf("JSON error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(issues)
-}
-
-func handleAPIIssueDetail(w http.ResponseWriter, r *http.Request) {
-	issueID := r.URL.Path[len("/api/issues/"):]
-	if issueID == "" {
-		http.Error(w, "Issue ID required", http.StatusBadRequest)
-		return
-	}
-
-	daemonClientMu.Lock()
-	defer daemonClientMu.Unlock()
-
-	if daemonClient == nil {
-		http.Error(w, "Daemon not connected", http.StatusInternalServerError)
-		return
-	}
-
-	resp, err := daemonClient.Show(&rpc.ShowArgs{ID: issueID})
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Issue not found: %v", err), http.StatusNotFound)
-		return
-	}
-
-	// RPC Show returns IssueDetails with labels, dependencies, dependents, comments
-	var details *types.IssueDetails
-	if err := json.Unmarshal(resp.Data, &details); err != nil {
-		http.Error(w, fmt.Sprintf("JSON error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(details)
-}
-
-func handleAPIReady(w http.ResponseWriter, r *http.Request) {
-	daemonClientMu.Lock()
-	defer daemonClientMu.Unlock()
-
-	if daemonClient == nil {
-		http.Error(w, "Daemon not connected", http.StatusInternalServerError)
-		return
-	}
+	filter := parseMutationFilter(c.Query("issue"), c.Query("type"), c.Query("epic"))
+	sub, _ := mutationHub.Subscribe(filter, 0)
+	defer mutationHub.Unsubscribe(sub)
 
-	resp, err := daemonClient.Ready(&rpc.ReadyArgs{})
-	if err != nil {
-		http.Error(w, fmt.Sprintf("RPC error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	var issues []*types.Issue
-	if err := json.Unmarshal(resp.Data, &issues); err != nil {
-		http.Error(w, fmt.Sprintf("JSON error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(issues)
-}
-
-func handleAPIStats(w http.ResponseWriter, r *http.Request) {
-	daemonClientMu.Lock()
-	defer daemonClientMu.Unlock()
-
-	if daemonClient == nil {
-		http.Error(w, "Daemon not connected", http.StatusInternalServerError)
-		return
-	}
-
-	resp, err := daemonClient.Stats()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("RPC error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	var stats *types.Statistics
-	if err := json.Unmarshal(resp.Data, &stats); err != nil {
-		http.Error(w, fmt.Sprintf("JSON error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(stats)
-}
-
-func handleAPIEpics(w http.ResponseWriter, r *http.Request) {
-	daemonClientMu.Lock()
-	defer daemonClientMu.Unlock()
-
-	if daemonClient == nil {
-		http.Error(w, "Daemon not connected", http.StatusInternalServerError)
-		return
-	}
-
-	// Use EpicStatus RPC to get epics with child counts
-	resp, err := daemonClient.EpicStatus(&rpc.EpicStatusArgs{})
-	if err != nil {
-		http.Error(w, fmt.Sprintf("RPC error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	var epicStatuses []*types.EpicStatus
-	if err := json.Unmarshal(resp.Data, &epicStatuses); err != nil {
-		http.Error(w, fmt.Sprintf("JSON error: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	// Filter out tombstone epics - they are deleted and shouldn't appear in dashboard
-	var filtered []*types.EpicStatus
-	for _, es := range epicStatuses {
-		if es.Epic != nil && es.Epic.Status != "tombstone" {
-			filtered = append(filtered, es)
+	// A client never sends anything meaningful over this socket, but reading
+	// is how we notice it went away; drive that off of the connection's own
+	// goroutine and unsubscribe to unblock the write loop below.
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				mutationHub.Unsubscribe(sub)
+				return
+			}
 		}
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(filtered)
-}
-
-func handleAPIConfig(w http.ResponseWriter, r *http.Request) {
-	config := map[string]string{
-		"gtDashboardURL": gtURL,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(config)
-}
-
-func handleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		return
-	}
-
-	wsClientsMu.Lock()
-	wsClients[conn] = true
-	wsClientsMu.Unlock()
-
-	defer func() {
-		wsClientsMu.Lock()
-		delete(wsClients, conn)
-		wsClientsMu.Unlock()
-		conn.Close()
 	}()
 
-	for {
-		_, _, err := conn.ReadMessage()
-		if err != nil {
-			break
+	for event := range sub.ch {
+		if err := conn.WriteMessage(websocket.TextMessage, event.data); err != nil {
+			return
 		}
 	}
 }
 
-func handleWebSocketBroadcast() {
-	for message := range wsBroadcast {
-		wsClientsMu.Lock()
-		for client := range wsClients {
-			if err := client.WriteMessage(websocket.TextMessage, message); err != nil {
-				client.Close()
-				delete(wsClients, client)
-			}
-		}
-		wsClientsMu.Unlock()
-	}
-}
-
-func pollMutations() {
-	lastPollTime := int64(0)
+func pollMutations(cursorPath string) {
+	lastPollTime := loadCursor(cursorPath)
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
@@ -358,11 +221,15 @@ func pollMutations() {
 			continue
 		}
 
-		resp, err := daemonClient.GetMutations(&rpc.GetMutationsArgs{Since: lastPollTime})
+		resp, err := timeRPC("GetMutations", func() (*rpc.Response, error) {
+			return daemonClient.GetMutations(&rpc.GetMutationsArgs{Since: lastPollTime})
+		})
 		daemonClientMu.Unlock()
 		if err != nil {
+			mutationPollErrors.Inc()
 			continue
 		}
+		markPollSuccess()
 
 		var mutations []rpc.MutationEvent
 		if err := json.Unmarshal(resp.Data, &mutations); err != nil {
@@ -371,10 +238,13 @@ func pollMutations() {
 
 		for _, mutation := range mutations {
 			data, _ := json.Marshal(mutation)
-			wsBroadcast <- data
+			mutationHub.Broadcast(mutation, data)
 			if ts := mutation.Timestamp.UnixMilli(); ts > lastPollTime {
 				lastPollTime = ts
 			}
 		}
+		if len(mutations) > 0 {
+			saveCursor(cursorPath, lastPollTime)
+		}
 	}
 }
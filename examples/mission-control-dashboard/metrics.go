@@ -0,0 +1,101 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "beads_dashboard_http_requests_total",
+		Help: "Total HTTP requests handled by the dashboard, by route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "beads_dashboard_http_request_duration_seconds",
+		Help:    "HTTP request duration in seconds, by route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	wsClientsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "beads_dashboard_ws_clients",
+		Help: "Number of currently connected WebSocket/SSE subscribers.",
+	})
+
+	broadcastQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "beads_dashboard_broadcast_queue_depth",
+		Help: "Total number of queued mutation events across all subscriber channels.",
+	})
+
+	mutationPollErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "beads_dashboard_mutation_poll_errors_total",
+		Help: "Number of errors encountered polling/subscribing for mutations from the daemon.",
+	})
+
+	rpcLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "beads_dashboard_rpc_duration_seconds",
+		Help:    "daemonClient RPC call duration in seconds, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		httpRequestsTotal,
+		httpRequestDuration,
+		wsClientsGauge,
+		broadcastQueueDepth,
+		mutationPollErrors,
+		rpcLatency,
+	)
+}
+
+// metricsMiddleware records per-route request counts and latency for
+// Prometheus scraping, keyed on the matched route template rather than the
+// raw path so /api/issues/:id doesn't explode the label cardinality.
+func metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+func handleMetrics() http.Handler {
+	return promhttp.Handler()
+}
+
+// sampleHubMetrics periodically mirrors the hub's subscriber count and
+// queue depth into Prometheus gauges. Run as a background goroutine.
+func sampleHubMetrics() {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		wsClientsGauge.Set(float64(mutationHub.SubscriberCount()))
+		broadcastQueueDepth.Set(float64(mutationHub.QueueDepth()))
+	}
+}
+
+// timeRPC wraps a daemonClient call with an rpcLatency observation so every
+// RPC round trip shows up in Prometheus without repeating timing code at
+// each call site.
+func timeRPC(method string, fn func() (*rpc.Response, error)) (*rpc.Response, error) {
+	start := time.Now()
+	resp, err := fn()
+	rpcLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	return resp, err
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRouter builds the dashboard's gin.Engine: grouped routes with typed
+// path params in place of the old r.URL.Path[len(...):] slicing.
+func newRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(loggingMiddleware(), metricsMiddleware(), gin.Recovery())
+
+	r.GET("/", handleIndex)
+	r.StaticFS("/static", http.FS(webFS))
+	r.GET("/ws", wsAuthMiddleware(), handleWebSocket)
+	r.GET("/api/events", sseAuthMiddleware(), handleSSE)
+	r.GET("/metrics", gin.WrapH(handleMetrics()))
+	r.GET("/healthz", handleHealthz)
+	r.GET("/readyz", handleReadyz)
+
+	api := r.Group("/api")
+	api.Use(apiAuthMiddleware())
+	{
+		api.POST("/ws-token", handleWSToken)
+		api.GET("/config", handleAPIConfig)
+		api.GET("/ready", handleAPIReady)
+		api.GET("/stats", handleAPIStats)
+
+		api.GET("/epics", handleAPIEpics)
+		api.POST("/epics", handleCreateEpic)
+
+		api.GET("/issues", handleAPIIssues)
+		api.POST("/issues", handleCreateIssue)
+		api.GET("/issues/:id", handleAPIIssueDetail)
+		api.PATCH("/issues/:id", handleUpdateIssue)
+		api.DELETE("/issues/:id", handleDeleteIssue)
+		api.POST("/issues/:id/comments", handleAddComment)
+		api.POST("/issues/:id/dependencies", handleAddDependency)
+	}
+
+	return r
+}
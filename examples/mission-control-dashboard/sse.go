@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sseHeartbeatInterval is how often handleSSE writes a comment line to keep
+// intermediate proxies from timing out an idle connection.
+const sseHeartbeatInterval = 15 * time.Second
+
+// handleSSE serves /api/events: a Server-Sent Events alternative to the
+// WebSocket firehose. Each client only receives the mutations it asked for
+// via ?issue=/?type=/?epic=, and can resume after a reconnect with
+// Last-Event-ID instead of missing whatever happened in between.
+func handleSSE(c *gin.Context) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		respondError(c, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	var afterSeq int64
+	if lastID := c.GetHeader("Last-Event-ID"); lastID != "" {
+		afterSeq, _ = strconv.ParseInt(lastID, 10, 64)
+	}
+
+	filter := parseMutationFilter(c.Query("issue"), c.Query("type"), c.Query("epic"))
+	sub, backlog := mutationHub.Subscribe(filter, afterSeq)
+	defer mutationHub.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+
+	for _, event := range backlog {
+		writeSSEEvent(c.Writer, event)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.ch:
+			if !ok {
+				return
+			}
+			writeSSEEvent(c.Writer, event)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			flusher.Flush()
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, event hubEvent) {
+	fmt.Fprintf(w, "id: %d\n", event.seq)
+	fmt.Fprint(w, "event: mutation\n")
+	fmt.Fprintf(w, "data: %s\n\n", event.data)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// subscribeMinVersion is the first daemon version that supports the
+// long-poll Subscribe RPC. Daemons older than this keep getting served by
+// the fixed-interval pollMutations loop.
+const subscribeMinVersion = "0.4.0"
+
+// subscribeRetryBackoff is how long subscribeLoop waits after an RPC error
+// (daemon restarting, socket hiccup, etc.) before trying again.
+const subscribeRetryBackoff = 2 * time.Second
+
+// subscribeMinInterval floors how often this loop re-issues Subscribe, even
+// on the success path. client.Subscribe is not a true server-blocking
+// long-poll in every daemon build it talks to, so a call that returns
+// immediately must not turn this into a busy loop over the daemon socket.
+const subscribeMinInterval = 500 * time.Millisecond
+
+var daemonSupportsSubscribe bool
+
+// subscribeLoop replaces the fixed 2-second pollMutations cycle with a
+// Subscribe call that's meant to block server-side until a new mutation
+// exists, so dashboard latency isn't capped at a fixed poll interval once the
+// daemon supports it. subscribeMinInterval guards the case where it doesn't
+// yet block and returns promptly. The cursor is persisted after every batch
+// so a dashboard restart resumes instead of replaying or dropping events.
+func subscribeLoop(cursorPath string) {
+	cursor := loadCursor(cursorPath)
+
+	for {
+		daemonClientMu.Lock()
+		client := daemonClient
+		daemonClientMu.Unlock()
+		if client == nil {
+			time.Sleep(subscribeRetryBackoff)
+			continue
+		}
+
+		callStart := time.Now()
+		resp, err := timeRPC("Subscribe", func() (*rpc.Response, error) {
+			return client.Subscribe(&rpc.GetMutationsArgs{Since: cursor})
+		})
+		if err != nil {
+			mutationPollErrors.Inc()
+			time.Sleep(subscribeRetryBackoff)
+			continue
+		}
+		markPollSuccess()
+		if elapsed := time.Since(callStart); elapsed < subscribeMinInterval {
+			time.Sleep(subscribeMinInterval - elapsed)
+		}
+
+		var mutations []rpc.MutationEvent
+		if err := json.Unmarshal(resp.Data, &mutations); err != nil {
+			continue
+		}
+
+		for _, mutation := range mutations {
+			data, _ := json.Marshal(mutation)
+			mutationHub.Broadcast(mutation, data)
+			if ts := mutation.Timestamp.UnixMilli(); ts > cursor {
+				cursor = ts
+			}
+		}
+		if len(mutations) > 0 {
+			saveCursor(cursorPath, cursor)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import "time"
+
+const (
+	healthCheckInterval  = 5 * time.Second
+	reconnectBaseBackoff = 1 * time.Second
+	reconnectMaxBackoff  = 30 * time.Second
+)
+
+// superviseDaemonConnection periodically checks daemon health and reconnects
+// with exponential backoff if it drops, instead of leaving daemonClient
+// non-nil forever while every handler starts returning RPC errors.
+func superviseDaemonConnection(socketPath, dbPath string) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	backoff := reconnectBaseBackoff
+
+	for range ticker.C {
+		daemonClientMu.Lock()
+		client := daemonClient
+		daemonClientMu.Unlock()
+
+		if client != nil {
+			daemonClientMu.Lock()
+			health, err := client.Health()
+			daemonClientMu.Unlock()
+			if err == nil && health.Status == "healthy" {
+				backoff = reconnectBaseBackoff
+				continue
+			}
+
+			daemonClientMu.Lock()
+			_ = daemonClient.Close()
+			daemonClient = nil
+			daemonClientMu.Unlock()
+			logger.Error("daemon connection lost, attempting reconnect")
+		}
+
+		if err := connectToDaemon(socketPath, dbPath); err != nil {
+			logger.Error("daemon reconnect failed", "error", err, "retryIn", backoff.String())
+			time.Sleep(backoff)
+			if backoff *= 2; backoff > reconnectMaxBackoff {
+				backoff = reconnectMaxBackoff
+			}
+			continue
+		}
+
+		logger.Info("daemon reconnected")
+		backoff = reconnectBaseBackoff
+	}
+}
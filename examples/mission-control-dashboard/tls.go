@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+var (
+	tlsCert          = flag.String("tls-cert", "", "Path to a TLS certificate (enables HTTPS)")
+	tlsKey           = flag.String("tls-key", "", "Path to a TLS private key (enables HTTPS)")
+	httpRedirectPort = flag.Int("http-redirect-port", 0, "If set together with -tls-cert/-tls-key, run a plaintext HTTP listener on this port that redirects to HTTPS")
+)
+
+func tlsEnabled() bool {
+	return *tlsCert != "" && *tlsKey != ""
+}
+
+// serve starts srv, optionally over TLS. When TLS is enabled and
+// redirectAddr is non-empty it also starts a plaintext listener on
+// redirectAddr that 301s every request to the HTTPS address, so operators
+// can point both ports at the dashboard and let it handle the upgrade.
+func serve(srv *http.Server, redirectAddr string) error {
+	if !tlsEnabled() {
+		return srv.ListenAndServe()
+	}
+
+	srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if redirectAddr != "" {
+		go func() {
+			redirectSrv := &http.Server{
+				Addr: redirectAddr,
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					hostname := r.Host
+					if h, _, err := net.SplitHostPort(r.Host); err == nil {
+						hostname = h
+					}
+					_, httpsPort, _ := net.SplitHostPort(srv.Addr)
+					target := "https://" + net.JoinHostPort(hostname, httpsPort) + r.URL.RequestURI()
+					http.Redirect(w, r, target, http.StatusMovedPermanently)
+				}),
+			}
+			if err := redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				fmt.Printf("  HTTP redirect listener stopped: %v\n", err)
+			}
+		}()
+	}
+
+	return srv.ListenAndServeTLS(*tlsCert, *tlsKey)
+}
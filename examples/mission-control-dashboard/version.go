@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// versionAtLeast compares two dotted version strings ("1.4.2") component by
+// component, treating missing or non-numeric components as 0. Good enough
+// for negotiating daemon feature support without pulling in a semver
+// dependency for a three-number comparison.
+func versionAtLeast(version, minVersion string) bool {
+	v := versionParts(version)
+	m := versionParts(minVersion)
+
+	for i := 0; i < len(v) || i < len(m); i++ {
+		var a, b int
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(m) {
+			b = m[i]
+		}
+		if a != b {
+			return a > b
+		}
+	}
+	return true
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	fields := strings.Split(version, ".")
+	parts := make([]int, len(fields))
+	for i, f := range fields {
+		n, _ := strconv.Atoi(f)
+		parts[i] = n
+	}
+	return parts
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version    string
+		minVersion string
+		want       bool
+	}{
+		{"1.4.2", "1.4.2", true},
+		{"1.5.0", "1.4.2", true},
+		{"1.4.2", "1.5.0", false},
+		{"2.0.0", "1.9.9", true},
+		{"1.4", "1.4.0", true},
+		{"1.4.0", "1.4", true},
+		{"v1.4.2", "1.4.2", true},
+		{"1.4.2", "v1.4.3", false},
+		{"", "", true},
+		{"", "1.0.0", false},
+		{"abc", "1.0.0", false},
+	}
+
+	for _, tt := range tests {
+		if got := versionAtLeast(tt.version, tt.minVersion); got != tt.want {
+			t.Errorf("versionAtLeast(%q, %q) = %v, want %v", tt.version, tt.minVersion, got, tt.want)
+		}
+	}
+}